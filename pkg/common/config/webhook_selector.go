@@ -0,0 +1,39 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// webhookTestSelectorEnv names the environment variable used to scope the
+// webhooks e2e suite to a subset of the cluster's namespaces/pods. It's a
+// JSON-encoded WebhookTestSelector, left unset to match everything.
+const webhookTestSelectorEnv = "WEBHOOK_TEST_SELECTOR"
+
+// WebhookTestSelector scopes the webhooks e2e suite to the namespaces/pods it
+// should exercise, so the same specs run against ROSA, OSD, and HyperShift
+// clusters whose namespace inventories differ instead of hard-coding names
+// like openshift-backplane/openshift-logging.
+type WebhookTestSelector struct {
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	PodSelector       *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// GetWebhookTestSelector reads the selector from WEBHOOK_TEST_SELECTOR,
+// falling back to an empty selector that matches every namespace/pod.
+func GetWebhookTestSelector() (*WebhookTestSelector, error) {
+	raw := os.Getenv(webhookTestSelectorEnv)
+	if raw == "" {
+		return &WebhookTestSelector{}, nil
+	}
+
+	var selector WebhookTestSelector
+	if err := json.Unmarshal([]byte(raw), &selector); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", webhookTestSelectorEnv, err)
+	}
+
+	return &selector, nil
+}
@@ -0,0 +1,151 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// admissionCELEnv is the CEL environment shared across specs, declaring the
+// single "status" variable ExpectAdmission/MatchAdmissionCEL evaluate
+// expressions against.
+var admissionCELEnv = newAdmissionCELEnv()
+
+func newAdmissionCELEnv() *cel.Env {
+	env, err := cel.NewEnv(cel.Variable("status", cel.DynType))
+	if err != nil {
+		panic(fmt.Sprintf("failed to build admission CEL environment: %v", err))
+	}
+	return env
+}
+
+// admissionStatus is the object CEL expressions evaluate against, synthesized
+// from a *apierrors.StatusError so specs can write expressions like
+// `status.code == 403 && status.message.contains("privileged namespace")` or
+// `status.reason == "Forbidden" && has(status.causes) && status.causes[0].field == "spec.tolerations"`
+// instead of hand-rolling per-webhook assertion helpers.
+type admissionStatus struct {
+	Code     int32                    `json:"code"`
+	Reason   string                   `json:"reason"`
+	Message  string                   `json:"message"`
+	Causes   []map[string]interface{} `json:"causes,omitempty"`
+	Groups   []string                 `json:"groups,omitempty"`
+	Warnings []string                 `json:"warnings,omitempty"`
+}
+
+// newAdmissionStatus extracts an admissionStatus from err, returning false if
+// err isn't a *apierrors.StatusError.
+func newAdmissionStatus(err error) (*admissionStatus, bool) {
+	statusErr, ok := err.(*apierrors.StatusError)
+	if !ok {
+		return nil, false
+	}
+
+	status := statusErr.ErrStatus
+	out := &admissionStatus{
+		Code:    status.Code,
+		Reason:  string(status.Reason),
+		Message: status.Message,
+	}
+
+	if status.Details != nil {
+		if status.Details.Group != "" {
+			out.Groups = append(out.Groups, status.Details.Group)
+		}
+		for _, cause := range status.Details.Causes {
+			out.Causes = append(out.Causes, map[string]interface{}{
+				"type":    string(cause.Type),
+				"message": cause.Message,
+				"field":   cause.Field,
+			})
+		}
+	}
+
+	return out, true
+}
+
+// ExpectAdmission asserts that err is a *apierrors.StatusError whose
+// synthesized status satisfies the CEL expression expr.
+func ExpectAdmission(err error, expr string) {
+	gomega.ExpectWithOffset(1, err).To(MatchAdmissionCEL(expr))
+}
+
+// MatchAdmissionCEL returns a Gomega matcher that compiles expr on each Match
+// call and evaluates it against the admissionStatus extracted from the actual error,
+// printing the compiled expression alongside the actual status on failure.
+func MatchAdmissionCEL(expr string) types.GomegaMatcher {
+	return &matchAdmissionCELMatcher{expr: expr}
+}
+
+type matchAdmissionCELMatcher struct {
+	expr   string
+	status *admissionStatus
+}
+
+func (m *matchAdmissionCELMatcher) Match(actual interface{}) (bool, error) {
+	err, ok := actual.(error)
+	if !ok {
+		return false, fmt.Errorf("MatchAdmissionCEL expects an error, got %T", actual)
+	}
+
+	status, ok := newAdmissionStatus(err)
+	if !ok {
+		return false, fmt.Errorf("MatchAdmissionCEL expects a *apierrors.StatusError, got %T", err)
+	}
+	m.status = status
+
+	ast, issues := admissionCELEnv.Compile(m.expr)
+	if issues != nil && issues.Err() != nil {
+		return false, fmt.Errorf("failed to compile CEL expression %q: %w", m.expr, issues.Err())
+	}
+
+	program, err := admissionCELEnv.Program(ast)
+	if err != nil {
+		return false, fmt.Errorf("failed to build CEL program for %q: %w", m.expr, err)
+	}
+
+	statusValue, err := statusToCELValue(status)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{"status": statusValue})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate CEL expression %q: %w", m.expr, err)
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("CEL expression %q did not evaluate to a bool", m.expr)
+	}
+
+	return result, nil
+}
+
+func (m *matchAdmissionCELMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("expected admission status to satisfy CEL expression:\n\t%s\ngot status:\n\t%+v", m.expr, m.status)
+}
+
+func (m *matchAdmissionCELMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("expected admission status not to satisfy CEL expression:\n\t%s\ngot status:\n\t%+v", m.expr, m.status)
+}
+
+// statusToCELValue round-trips status through JSON into a map[string]any, the
+// shape cel-go's dynamic type adapter understands natively.
+func statusToCELValue(status *admissionStatus) (map[string]interface{}, error) {
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal admission status: %w", err)
+	}
+
+	var value map[string]interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admission status: %w", err)
+	}
+
+	return value, nil
+}
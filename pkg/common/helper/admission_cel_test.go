@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newForbiddenStatusError(message string, causes ...metav1.StatusCause) *apierrors.StatusError {
+	gr := schema.GroupResource{Group: "", Resource: "pods"}
+	err := apierrors.NewForbidden(gr, "test-pod", errors.New(message)).(*apierrors.StatusError)
+	if len(causes) > 0 {
+		err.ErrStatus.Details = &metav1.StatusDetails{Causes: causes}
+	}
+	return err
+}
+
+func TestMatchAdmissionCEL(t *testing.T) {
+	err := newForbiddenStatusError("pods in privileged namespace are not allowed", metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "toleration not permitted",
+		Field:   "spec.tolerations",
+	})
+
+	tests := []struct {
+		name string
+		expr string
+		err  error
+		want bool
+	}{
+		{
+			name: "matches code and message",
+			expr: `status.code == 403 && status.message.contains("privileged namespace")`,
+			err:  err,
+			want: true,
+		},
+		{
+			name: "matches reason and cause field",
+			expr: `status.reason == "Forbidden" && has(status.causes) && status.causes[0].field == "spec.tolerations"`,
+			err:  err,
+			want: true,
+		},
+		{
+			name: "expression false",
+			expr: `status.code == 500`,
+			err:  err,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := MatchAdmissionCEL(tt.expr)
+			got, matchErr := matcher.Match(tt.err)
+			if matchErr != nil {
+				t.Fatalf("Match returned error: %v", matchErr)
+			}
+			if got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAdmissionCELNonStatusError(t *testing.T) {
+	matcher := MatchAdmissionCEL(`status.code == 403`)
+	if _, err := matcher.Match(errors.New("not a status error")); err == nil {
+		t.Fatal("expected an error for a non-StatusError input")
+	}
+}
+
+func TestNewAdmissionStatus(t *testing.T) {
+	err := newForbiddenStatusError("denied", metav1.StatusCause{Field: "spec.tolerations"})
+
+	status, ok := newAdmissionStatus(err)
+	if !ok {
+		t.Fatal("expected ok=true for a StatusError")
+	}
+	if status.Code != 403 {
+		t.Errorf("Code = %d, want 403", status.Code)
+	}
+	if len(status.Causes) != 1 || status.Causes[0]["field"] != "spec.tolerations" {
+		t.Errorf("Causes = %+v, want one cause with field spec.tolerations", status.Causes)
+	}
+}
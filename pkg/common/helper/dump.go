@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// dumpPodLogTailLines bounds how much of each container's log we keep, so a
+// crash-looping pod doesn't dump megabytes into the artifact bundle.
+const dumpPodLogTailLines = int64(500)
+
+// DumpPodLogs writes the last dumpPodLogTailLines of every container in every
+// pod matching labelSelector in namespace, plus recent namespace events, into
+// dir. Suites call this from an AfterEach/AfterAll on spec failure so CI
+// failures are actionable without re-running with --v=trace.
+func (h *H) DumpPodLogs(ctx context.Context, namespace, labelSelector, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create artifact dir %s: %w", dir, err)
+	}
+
+	// The failed spec may have left h impersonating an unprivileged user
+	// (e.g. a random user a "blocked" case impersonated), which can't list
+	// pods or read logs in namespace. Reset to the admin config so the dump
+	// itself doesn't fail with Forbidden.
+	h.Impersonate(rest.ImpersonationConfig{})
+
+	clientset, err := kubernetes.NewForConfig(h.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to build clientset: %w", err)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return fmt.Errorf("failed to list pods in %s: %w", namespace, err)
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			logs, logsErr := clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+				Container: container.Name,
+				TailLines: &dumpPodLogTailLines,
+			}).DoRaw(ctx)
+			if logsErr != nil {
+				logs = []byte(fmt.Sprintf("failed to fetch logs for %s/%s: %v", pod.Name, container.Name, logsErr))
+			}
+
+			path := filepath.Join(dir, fmt.Sprintf("%s_%s.log", pod.Name, container.Name))
+			if err := os.WriteFile(path, logs, 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+	}
+
+	if err := h.dumpEvents(ctx, clientset, namespace, dir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (h *H) dumpEvents(ctx context.Context, clientset *kubernetes.Clientset, namespace, dir string) error {
+	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list events in %s: %w", namespace, err)
+	}
+
+	path := filepath.Join(dir, "events.log")
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	for _, event := range events.Items {
+		fmt.Fprintf(f, "%s\t%s\t%s/%s\t%s\n",
+			event.LastTimestamp.Time.Format(time.RFC3339),
+			event.Type, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+	}
+
+	return nil
+}
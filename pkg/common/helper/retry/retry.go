@@ -0,0 +1,154 @@
+// Package retry wraps sigs.k8s.io/e2e-framework's resources.Resources client
+// with retry-on-transient-error behavior. The webhooks e2e suite exercises
+// the validation-webhook daemonset while it is actively being rolled, which
+// means Get/Create/Delete/Update/List calls routinely hit short-lived
+// timeouts, throttling, or "connection refused" while a webhook pod restarts.
+// Wrapping the client here lets suites retry those calls without hand-rolling
+// backoff loops around every assertion.
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+)
+
+// DefaultTimeout is the retry budget used when a caller doesn't specify one.
+// It mirrors the pod-creation wait duration used throughout the webhooks
+// suite, since that's the dominant caller today.
+const DefaultTimeout = time.Minute
+
+const (
+	initialBackoff = 500 * time.Millisecond
+	backoffFactor  = 2.0
+	backoffJitter  = 0.1
+)
+
+// Client wraps a resources.Resources client, retrying calls that fail with
+// transient errors instead of surfacing them to the caller immediately.
+type Client struct {
+	*resources.Resources
+
+	// Timeout bounds the total wall-clock time spent retrying a single call,
+	// enforced via context.WithTimeout around the backoff loop (not just the
+	// per-step backoff cap).
+	Timeout time.Duration
+}
+
+// New wraps r so its calls retry on transient errors for up to timeout. A
+// non-positive timeout falls back to DefaultTimeout.
+func New(r *resources.Resources, timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{Resources: r, Timeout: timeout}
+}
+
+// CreateWithRetry retries Create on transient errors.
+func (c *Client) CreateWithRetry(ctx context.Context, obj k8s.Object, opts ...resources.CreateOption) error {
+	return c.retry(ctx, func() error { return c.Resources.Create(ctx, obj, opts...) })
+}
+
+// GetWithRetry retries Get on transient errors.
+func (c *Client) GetWithRetry(ctx context.Context, name, namespace string, obj k8s.Object, opts ...resources.GetOption) error {
+	return c.retry(ctx, func() error { return c.Resources.Get(ctx, name, namespace, obj, opts...) })
+}
+
+// DeleteWithRetry retries Delete on transient errors.
+func (c *Client) DeleteWithRetry(ctx context.Context, obj k8s.Object, opts ...resources.DeleteOption) error {
+	return c.retry(ctx, func() error { return c.Resources.Delete(ctx, obj, opts...) })
+}
+
+// UpdateWithRetry retries Update on transient errors.
+func (c *Client) UpdateWithRetry(ctx context.Context, obj k8s.Object, opts ...resources.UpdateOption) error {
+	return c.retry(ctx, func() error { return c.Resources.Update(ctx, obj, opts...) })
+}
+
+// ListWithRetry retries List on transient errors.
+func (c *Client) ListWithRetry(ctx context.Context, obj k8s.ObjectList, opts ...resources.ListOption) error {
+	return c.retry(ctx, func() error { return c.Resources.List(ctx, obj, opts...) })
+}
+
+// retry runs do with jittered exponential backoff until it succeeds, returns
+// a non-retryable error, or c.Timeout elapses. c.Timeout bounds the total
+// wall-clock time spent retrying, not just the per-step backoff cap.
+func (c *Client) retry(ctx context.Context, do func() error) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	backoff := wait.Backoff{
+		Duration: initialBackoff,
+		Factor:   backoffFactor,
+		Jitter:   backoffJitter,
+		Steps:    maxSteps(c.Timeout),
+		Cap:      c.Timeout,
+	}
+
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(context.Context) (bool, error) {
+		lastErr = do()
+		if lastErr == nil {
+			return true, nil
+		}
+		if !IsRetryable(lastErr) {
+			return false, lastErr
+		}
+		return false, nil
+	})
+	if err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
+		return err
+	}
+	return lastErr
+}
+
+func maxSteps(timeout time.Duration) int {
+	steps := int(timeout / initialBackoff)
+	if steps < 1 {
+		steps = 1
+	}
+	return steps
+}
+
+// IsRetryable reports whether err represents a transient condition worth
+// retrying rather than failing the calling spec outright: network errors,
+// API server timeouts/throttling/internal errors, or the connection-refused
+// errors the API server surfaces while the validation-webhook pods are
+// mid-restart and haven't registered with their Service endpoints yet.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	switch {
+	case apierrors.IsServerTimeout(err):
+		return true
+	case apierrors.IsTooManyRequests(err):
+		return true
+	case apierrors.IsInternalError(err):
+		return true
+	}
+
+	return isWebhookConnectionRefused(err)
+}
+
+// isWebhookConnectionRefused matches the errors the API server wraps webhook
+// calls in when the target Service has no ready endpoints, which is exactly
+// the window the validation-webhook daemonset is unavailable during a roll.
+func isWebhookConnectionRefused(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "no endpoints available for service") ||
+		strings.Contains(msg, "failed calling webhook")
+}
@@ -0,0 +1,96 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeNetError struct{}
+
+func (fakeNetError) Error() string   { return "fake net error" }
+func (fakeNetError) Timeout() bool   { return true }
+func (fakeNetError) Temporary() bool { return true }
+
+var _ net.Error = fakeNetError{}
+
+func TestIsRetryable(t *testing.T) {
+	gr := schema.GroupResource{Group: "", Resource: "pods"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"net error", fakeNetError{}, true},
+		{"server timeout", apierrors.NewServerTimeout(gr, "create", 1), true},
+		{"too many requests", apierrors.NewTooManyRequests("throttled", 1), true},
+		{"internal error", apierrors.NewInternalError(errors.New("boom")), true},
+		{"connection refused", errors.New(`Post "https://webhook.svc:443/validate": dial tcp: connect: connection refused`), true},
+		{"no endpoints", errors.New("failed calling webhook: no endpoints available for service \"validation-webhook\""), true},
+		{"not found", apierrors.NewNotFound(gr, "name"), false},
+		{"forbidden", apierrors.NewForbidden(gr, "name", errors.New("denied")), false},
+		{"generic error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDefaultsTimeout(t *testing.T) {
+	c := New(nil, 0)
+	if c.Timeout != DefaultTimeout {
+		t.Errorf("Timeout = %v, want %v", c.Timeout, DefaultTimeout)
+	}
+
+	c = New(nil, -1)
+	if c.Timeout != DefaultTimeout {
+		t.Errorf("Timeout = %v, want %v", c.Timeout, DefaultTimeout)
+	}
+
+	const custom = 30
+	c = New(nil, custom)
+	if c.Timeout != custom {
+		t.Errorf("Timeout = %v, want %v", c.Timeout, custom)
+	}
+}
+
+func TestMaxSteps(t *testing.T) {
+	if got := maxSteps(0); got != 1 {
+		t.Errorf("maxSteps(0) = %d, want 1", got)
+	}
+	if got := maxSteps(initialBackoff * 10); got != 10 {
+		t.Errorf("maxSteps(10x) = %d, want 10", got)
+	}
+}
+
+// TestRetryBoundsWallClock asserts that a persistently-retryable error
+// doesn't spin past c.Timeout, even though the per-step backoff Cap alone
+// wouldn't enforce that.
+func TestRetryBoundsWallClock(t *testing.T) {
+	c := &Client{Timeout: 200 * time.Millisecond}
+
+	start := time.Now()
+	err := c.retry(context.Background(), func() error {
+		return errors.New("failed calling webhook: connection refused")
+	})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected a persistently-retryable error to eventually return an error")
+	}
+	if elapsed > time.Second {
+		t.Errorf("retry ran for %v, expected it to stop near the %v timeout", elapsed, c.Timeout)
+	}
+}
@@ -1,6 +1,10 @@
 package labels
 
-import "github.com/onsi/ginkgo/v2"
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+)
 
 var (
 	Informing = ginkgo.Label("Informing")
@@ -13,3 +17,10 @@ var (
 	STS         = ginkgo.Label("STS")
 	PrivateLink = ginkgo.Label("PrivateLink")
 )
+
+// Webhook returns a label scoping a spec to a single managed-cluster
+// validating webhook, e.g. Webhook("namespace") produces "Webhook.namespace",
+// letting a run be filtered down to the specs for just that webhook.
+func Webhook(name string) ginkgo.Labels {
+	return ginkgo.Label(fmt.Sprintf("Webhook.%s", name))
+}
@@ -0,0 +1,287 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/helper/retry"
+	"github.com/openshift/osde2e/pkg/common/labels"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// verdict is the outcome a WebhookCase expects the apiserver to return for
+// its object/persona combination.
+type verdict int
+
+const (
+	allowed verdict = iota
+	blocked
+)
+
+// WebhookCase is one admission check against a single managed-cluster
+// validating webhook: who's submitting the request, what they're submitting,
+// and whether the webhook is expected to allow or block it. Cases share the
+// impersonation helpers (asUser/asDedicatedAdmin/asServiceAccount) and the
+// daemonset readiness gate already used by the rest of the suite.
+type WebhookCase struct {
+	// Webhook names the validating webhook under test, used to build the
+	// Webhook.<name> label so a run can be filtered to it.
+	Webhook string
+	// Persona describes who's making the request, for the spec description.
+	Persona string
+	// AsClient builds the impersonated client the request is made as.
+	AsClient func(h *helper.H) *retry.Client
+	// NewObject builds the object submitted to the apiserver.
+	NewObject func() k8s.Object
+	// Verdict is the expected admission outcome.
+	Verdict verdict
+	// ExpectCEL, when set, replaces the default "status.code == 403" check
+	// for a blocked case with a richer CEL expression evaluated against the
+	// synthesized admission status (see helper.ExpectAdmission).
+	ExpectCEL string
+	// Action is the admission action under test. "" (the default) submits
+	// NewObject via Create. "delete" instead submits a Delete of NewObject,
+	// for webhooks like hiveownership that guard modification of existing
+	// Hive-managed resources rather than new object creation. "update"
+	// fetches the live object NewObject describes and submits NewObject's
+	// result as an Update, for webhooks that register only for UPDATE
+	// against an existing singleton (e.g. identityprovider,
+	// techpreview-noupgrade) where a Create would just return 409
+	// AlreadyExists without ever reaching the webhook.
+	Action string
+}
+
+func (c WebhookCase) description() string {
+	return fmt.Sprintf("%s: %s", c.Webhook, c.Persona)
+}
+
+// webhookCases enumerates the SRE-owned validating webhooks exercised beyond
+// pod-scheduling (covered separately in webhooks.go, since its specs assert multiple
+// personas/namespaces within a single It). Each entry is independently
+// labeled via labels.Webhook so it can be run or skipped on its own.
+var webhookCases = []WebhookCase{
+	{
+		Webhook:  "namespace",
+		Persona:  "random user creating a reserved openshift-* namespace is blocked",
+		AsClient: func(h *helper.H) *retry.Client { return asUser(h, "majora") },
+		NewObject: func() k8s.Object {
+			return newUnstructured("v1", "Namespace", "", randomName("openshift-e2e"))
+		},
+		Verdict: blocked,
+	},
+	{
+		Webhook:  "namespace",
+		Persona:  "dedicated-admin creating a customer namespace is allowed",
+		AsClient: asDedicatedAdmin,
+		NewObject: func() k8s.Object {
+			return newUnstructured("v1", "Namespace", "", randomName("osde2e"))
+		},
+		Verdict: allowed,
+	},
+	{
+		// dedicated-admins have subscription create rights in their own
+		// projects; the webhook is what denies them in the redhat-managed
+		// openshift-operators namespace specifically, not RBAC.
+		Webhook:  "subscription",
+		Persona:  "dedicated-admin creating a Subscription in openshift-operators is blocked",
+		AsClient: asDedicatedAdmin,
+		NewObject: func() k8s.Object {
+			return newUnstructured("operators.coreos.com/v1alpha1", "Subscription", "openshift-operators", randomName("osde2e-sub"))
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("openshift-operators")`,
+	},
+	{
+		// dedicated-admins' RBAC includes creating ClusterRoleBindings (so
+		// they can grant roles across namespaces); the sre-clusterrolebinding
+		// webhook is the thing that actually stops them from using that to
+		// grant themselves cluster-admin, so it must be the persona exercised
+		// here rather than an unprivileged user who'd never pass authz.
+		Webhook:  "clusterrolebinding",
+		Persona:  "dedicated-admin binding cluster-admin is blocked",
+		AsClient: asDedicatedAdmin,
+		NewObject: func() k8s.Object {
+			obj := newUnstructured("rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "", randomName("osde2e-crb"))
+			Expect(unstructured.SetNestedField(obj.Object, "cluster-admin", "roleRef", "name")).To(Succeed())
+			return obj
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.reason == "Forbidden" && status.message.contains("cluster-admin")`,
+	},
+	{
+		Webhook:  "identityprovider",
+		Persona:  "dedicated-admin modifying cluster identity providers is blocked",
+		AsClient: asDedicatedAdmin,
+		Action:   "update",
+		NewObject: func() k8s.Object {
+			// The identityprovider webhook registers for UPDATE, not CREATE,
+			// and "cluster" already exists, so this must be submitted as an
+			// update of the live object (see registerWebhookMatrix's
+			// "update" Action) rather than a Create, which would just return
+			// 409 AlreadyExists.
+			obj := newUnstructured("config.openshift.io/v1", "OAuth", "", "cluster")
+			Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{}, "spec", "identityProviders")).To(Succeed())
+			return obj
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("identity provider")`,
+	},
+	{
+		// sre-scc exists to deny exactly the RoleBinding a backplane-cluster-
+		// admin's RBAC would otherwise let them create, so it's exercised as
+		// that persona rather than an unprivileged user who'd fail authz
+		// before the webhook is ever reached.
+		Webhook:  "sre-scc",
+		Persona:  "backplane-cluster-admin binding to privileged SCC is blocked",
+		AsClient: asBackplaneClusterAdmin,
+		NewObject: func() k8s.Object {
+			obj := newUnstructured("rbac.authorization.k8s.io/v1", "RoleBinding", "default", randomName("osde2e-scc-binding"))
+			Expect(unstructured.SetNestedMap(obj.Object, map[string]interface{}{
+				"apiGroup": "rbac.authorization.k8s.io",
+				"kind":     "ClusterRole",
+				"name":     "system:openshift:scc:privileged",
+			}, "roleRef")).To(Succeed())
+			Expect(unstructured.SetNestedSlice(obj.Object, []interface{}{
+				map[string]interface{}{
+					"apiGroup": "rbac.authorization.k8s.io",
+					"kind":     "User",
+					"name":     "backplane-cluster-admin",
+				},
+			}, "subjects")).To(Succeed())
+			return obj
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("scc")`,
+	},
+	{
+		Webhook:  "techpreview-noupgrade",
+		Persona:  "dedicated-admin enabling TechPreviewNoUpgrade is blocked",
+		AsClient: asDedicatedAdmin,
+		Action:   "update",
+		NewObject: func() k8s.Object {
+			// Same reasoning as identityprovider above: FeatureGate/cluster
+			// already exists and the webhook registers for UPDATE, so this
+			// must go through as an update, not a Create.
+			obj := newUnstructured("config.openshift.io/v1", "FeatureGate", "", "cluster")
+			Expect(unstructured.SetNestedField(obj.Object, "TechPreviewNoUpgrade", "spec", "featureSet")).To(Succeed())
+			return obj
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("TechPreviewNoUpgrade")`,
+	},
+	{
+		// Hive-owned resources are meant to be untouchable even by a
+		// highly-privileged SRE persona, not just an unprivileged one - the
+		// hiveownership webhook is what must deny this, not RBAC.
+		Webhook:  "hiveownership",
+		Persona:  "backplane-cluster-admin deleting the Hive-managed pull-secret is blocked",
+		AsClient: asBackplaneClusterAdmin,
+		Action:   "delete",
+		NewObject: func() k8s.Object {
+			// pull-secret is synced onto every managed cluster by Hive and
+			// exists prior to this spec running, so deleting it (rather than
+			// creating a new, unowned object) is what actually exercises the
+			// hiveownership webhook.
+			return newUnstructured("v1", "Secret", "openshift-config", "pull-secret")
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("hive")`,
+	},
+	{
+		// dedicated-admins can create PrometheusRules in their own projects;
+		// the webhook is what denies them in the reserved
+		// openshift-monitoring namespace specifically.
+		Webhook:  "prometheusrule",
+		Persona:  "dedicated-admin creating a PrometheusRule in openshift-monitoring is blocked",
+		AsClient: asDedicatedAdmin,
+		NewObject: func() k8s.Object {
+			return newUnstructured("monitoring.coreos.com/v1", "PrometheusRule", "openshift-monitoring", randomName("osde2e-rule"))
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("openshift-monitoring")`,
+	},
+	{
+		// ImageContentSourcePolicy is cluster-scoped and governs image
+		// mirroring for the whole cluster; a backplane-cluster-admin's RBAC
+		// would otherwise let them create one, so that's the persona needed
+		// to reach the imagecontentpolicies webhook rather than RBAC.
+		Webhook:  "imagecontentpolicies",
+		Persona:  "backplane-cluster-admin creating an ImageContentSourcePolicy is blocked",
+		AsClient: asBackplaneClusterAdmin,
+		NewObject: func() k8s.Object {
+			return newUnstructured("operator.openshift.io/v1alpha1", "ImageContentSourcePolicy", "", randomName("osde2e-icsp"))
+		},
+		Verdict:   blocked,
+		ExpectCEL: `status.code == 403 && status.message.contains("ImageContentSourcePolicy")`,
+	},
+}
+
+// registerWebhookMatrix registers webhookCases as specs. It's called from
+// within the suite's Describe(suiteName, ...) container (see webhooks.go) so
+// these specs inherit the suite's platform labels and its failure-artifact
+// AfterEach/AfterAll hooks instead of living in a disconnected top-level
+// container.
+func registerWebhookMatrix() {
+	ginkgo.Describe("webhook matrix", func() {
+		for _, c := range webhookCases {
+			c := c
+			ginkgo.It(c.description(), labels.Webhook(c.Webhook), func(ctx context.Context) {
+				client := c.AsClient(h)
+				obj := c.NewObject()
+
+				var err error
+				switch c.Action {
+				case "delete":
+					err = client.DeleteWithRetry(ctx, obj)
+				case "update":
+					// obj is the desired state but carries no resourceVersion
+					// since it wasn't read from the live object; fetch that
+					// first so the update isn't rejected as a stale write
+					// before the webhook even runs.
+					u, ok := obj.(*unstructured.Unstructured)
+					Expect(ok).To(BeTrue(), "update action requires an unstructured.Unstructured object")
+					existing := &unstructured.Unstructured{}
+					existing.SetGroupVersionKind(u.GroupVersionKind())
+					if err = client.GetWithRetry(ctx, u.GetName(), u.GetNamespace(), existing); err == nil {
+						u.SetResourceVersion(existing.GetResourceVersion())
+						err = client.UpdateWithRetry(ctx, u)
+					}
+				default:
+					err = client.CreateWithRetry(ctx, obj)
+				}
+
+				switch c.Verdict {
+				case blocked:
+					expr := c.ExpectCEL
+					if expr == "" {
+						expr = `status.code == 403`
+					}
+					helper.ExpectAdmission(err, expr)
+				case allowed:
+					Expect(err).ShouldNot(HaveOccurred(), "expected object to be admitted")
+					Expect(asUser(h, "").DeleteWithRetry(ctx, obj)).To(Succeed(), "failed to clean up admitted object")
+				}
+			}, ginkgo.SpecTimeout(createPodWaitDuration.Seconds()))
+		}
+	})
+}
+
+func newUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	return obj
+}
+
+func randomName(prefix string) string {
+	return envconf.RandomName(prefix, 12)
+}
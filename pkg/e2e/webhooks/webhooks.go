@@ -3,17 +3,24 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/openshift/osde2e/pkg/common/alert"
+	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/helper/retry"
 	"github.com/openshift/osde2e/pkg/common/labels"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/e2e-framework/klient/k8s"
 	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
@@ -32,8 +39,30 @@ const (
 	daemonsetName = "validation-webhook"
 	configMapName = "webhook-cert"
 	secretName    = "webhook-cert"
+
+	// maxNamespacesPerBucket bounds how many discovered namespaces the
+	// "are blocked" spec exercises per bucket, so a cluster with dozens of
+	// openshift-* namespaces doesn't blow through its SpecTimeout.
+	maxNamespacesPerBucket = 3
 )
 
+// srePrivilegedNamespaces are the namespaces the pod-scheduling webhook
+// treats as privileged (master/infra tolerations allowed for the
+// dedicated-admin-project ServiceAccount, forbidden for dedicated-admin and
+// ordinary users). This is the same set the
+// "are allowed" spec is entitled to create pods in, so it's kept as the
+// single source of truth for both specs.
+var srePrivilegedNamespaces = []string{"openshift-backplane"}
+
+func isSREPrivilegedNamespace(name string) bool {
+	for _, ns := range srePrivilegedNamespaces {
+		if ns == name {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 	alert.RegisterGinkgoAlert(suiteName, "SD-SREP", "", "sd-cicd-alerts", "sd-cicd@redhat.com", 4)
 }
@@ -45,76 +74,162 @@ var _ = ginkgo.BeforeSuite(func() {
 })
 
 var _ = Describe(suiteName, ginkgo.Ordered, func() {
+	ginkgo.AfterEach(func(ctx context.Context) {
+		dumpArtifactsOnFailure(ctx)
+	})
+
+	ginkgo.AfterAll(func(ctx context.Context) {
+		dumpArtifactsOnFailure(ctx)
+	})
+
 	ginkgo.It("exists and is running", func(ctx context.Context) {
 		client := asUser(h, "")
 
 		ginkgo.By("checking the namespace exists")
-		err := client.Get(ctx, namespaceName, namespaceName, &v1.Namespace{})
+		err := client.GetWithRetry(ctx, namespaceName, namespaceName, &v1.Namespace{})
 		Expect(err).ShouldNot(HaveOccurred(), "project should have been created")
 
 		ginkgo.By("checking the configmaps exist")
-		err = client.Get(ctx, configMapName, namespaceName, &v1.ConfigMap{})
+		err = client.GetWithRetry(ctx, configMapName, namespaceName, &v1.ConfigMap{})
 		Expect(err).ShouldNot(HaveOccurred(), "failed to get config map %s", configMapName)
 
 		ginkgo.By("checking the secret exists")
-		err = client.Get(ctx, secretName, namespaceName, &v1.Secret{})
+		err = client.GetWithRetry(ctx, secretName, namespaceName, &v1.Secret{})
 		Expect(err).ShouldNot(HaveOccurred(), "failed to get secret %s", secretName)
 
 		ginkgo.By("checking the service exists")
-		err = client.Get(ctx, serviceName, namespaceName, &v1.Service{})
+		err = client.GetWithRetry(ctx, serviceName, namespaceName, &v1.Service{})
 		Expect(err).ShouldNot(HaveOccurred(), "no Service named %s found.", serviceName)
 
 		ginkgo.By("checking the daemonset exists")
-		err = waitForDaemonSetAvailable(client, daemonsetName, namespaceName)
+		err = waitForDaemonSetAvailable(client.Resources, daemonsetName, namespaceName)
 		Expect(err).ShouldNot(HaveOccurred(), "no DaemonSet named %s found.", daemonsetName)
 	})
 
-	ginkgo.Describe("created pods scheduled onto master and infra nodes", func() {
-		const privilegedNamespace = "openshift-backplane"
-		const unprivilegedNamespace = "openshift-logging"
-		var pod *v1.Pod
+	ginkgo.Describe("created pods scheduled onto master and infra nodes", labels.Webhook("pod-scheduling"), func() {
+		var pods []*v1.Pod
 
 		ginkgo.BeforeEach(func() {
-			name := envconf.RandomName("osde2e", 12)
-			pod = newTestPod(name)
+			pods = nil
 		})
 
 		ginkgo.AfterEach(func(ctx context.Context) {
-			err := asUser(h, "").Delete(ctx, pod)
-			if !apierrors.IsNotFound(err) {
-				Expect(err).ShouldNot(HaveOccurred(), "failed to delete test pod")
+			client := asUser(h, "")
+			for _, p := range pods {
+				err := client.DeleteWithRetry(ctx, p)
+				if !apierrors.IsNotFound(err) {
+					Expect(err).ShouldNot(HaveOccurred(), "failed to delete test pod")
+				}
 			}
 		})
 
 		ginkgo.It("are blocked", func(ctx context.Context) {
-			ginkgo.By("impersonating dedicated-admin and using a privileged namespace")
-			pod = withNamespace(pod, privilegedNamespace)
-			err := asDedicatedAdmin(h).Create(ctx, pod)
-			Expect(apierrors.IsForbidden(err)).To(BeTrue(), "expected forbidden error", err)
-
-			ginkgo.By("impersonating a random user and using a privileged namespace")
-			client := asUser(h, "majora")
-			err = client.Create(ctx, pod)
-			Expect(apierrors.IsForbidden(err)).To(BeTrue(), "expected forbidden error", err)
-
-			ginkgo.By("impersonating a random user and using an unprivileged namespace")
-			err = client.Create(ctx, withNamespace(pod, unprivilegedNamespace))
-			Expect(apierrors.IsForbidden(err)).To(BeTrue(), "expected forbidden error", err)
+			selector, err := config.GetWebhookTestSelector()
+			Expect(err).ShouldNot(HaveOccurred(), "failed to load webhook test selector")
+
+			privilegedNamespaces, unprivilegedNamespaces, err := discoverNamespaces(ctx, asUser(h, ""), selector)
+			Expect(err).ShouldNot(HaveOccurred(), "failed to discover namespaces")
+
+			privilegedNamespaces = boundNamespaces(privilegedNamespaces, maxNamespacesPerBucket)
+			unprivilegedNamespaces = boundNamespaces(unprivilegedNamespaces, maxNamespacesPerBucket)
+
+			for _, privilegedNamespace := range privilegedNamespaces {
+				ginkgo.By(fmt.Sprintf("impersonating dedicated-admin and using privileged namespace %s", privilegedNamespace))
+				p := withPodSelectorLabels(withNamespace(newTestPod(envconf.RandomName("osde2e", 12)), privilegedNamespace), selector)
+				pods = append(pods, p)
+				err := asDedicatedAdmin(h).CreateWithRetry(ctx, p)
+				Expect(apierrors.IsForbidden(err)).To(BeTrue(), "expected forbidden error", err)
+
+				ginkgo.By(fmt.Sprintf("impersonating a random user and using privileged namespace %s", privilegedNamespace))
+				p2 := withPodSelectorLabels(withNamespace(newTestPod(envconf.RandomName("osde2e", 12)), privilegedNamespace), selector)
+				pods = append(pods, p2)
+				err = asUser(h, "majora").CreateWithRetry(ctx, p2)
+				Expect(apierrors.IsForbidden(err)).To(BeTrue(), "expected forbidden error", err)
+			}
+
+			for _, unprivilegedNamespace := range unprivilegedNamespaces {
+				ginkgo.By(fmt.Sprintf("impersonating a random user and using unprivileged namespace %s", unprivilegedNamespace))
+				p := withPodSelectorLabels(withNamespace(newTestPod(envconf.RandomName("osde2e", 12)), unprivilegedNamespace), selector)
+				pods = append(pods, p)
+				err := asUser(h, "majora").CreateWithRetry(ctx, p)
+				Expect(apierrors.IsForbidden(err)).To(BeTrue(), "expected forbidden error", err)
+			}
 		}, ginkgo.SpecTimeout(createPodWaitDuration.Seconds()+deletePodWaitDuration.Seconds()))
 
 		ginkgo.It("are allowed", func(ctx context.Context) {
-			ginkgo.By("impersonating dedicated-admin-project ServiceAccount")
+			selector, err := config.GetWebhookTestSelector()
+			Expect(err).ShouldNot(HaveOccurred(), "failed to load webhook test selector")
+
+			// Restricted to srePrivilegedNamespaces, not the broader
+			// discovered privileged set: the dedicated-admin-project
+			// ServiceAccount only has create rights in the namespaces it's
+			// explicitly entitled to (e.g. openshift-backplane), not every
+			// openshift-* namespace.
 			client := asServiceAccount(h, fmt.Sprintf("system:serviceaccount:%s:dedicated-admin-project", h.CurrentProject()))
-			err := client.Create(ctx, withNamespace(pod, privilegedNamespace))
-			Expect(err).ShouldNot(HaveOccurred(), "failed to create pod")
+			for _, privilegedNamespace := range srePrivilegedNamespaces {
+				ginkgo.By(fmt.Sprintf("impersonating dedicated-admin-project ServiceAccount in %s", privilegedNamespace))
+				p := withPodSelectorLabels(withNamespace(newTestPod(envconf.RandomName("osde2e", 12)), privilegedNamespace), selector)
+				pods = append(pods, p)
+				err := client.CreateWithRetry(ctx, p)
+				Expect(err).ShouldNot(HaveOccurred(), "failed to create pod")
+			}
 		}, ginkgo.SpecTimeout(createPodWaitDuration.Seconds()+deletePodWaitDuration.Seconds()))
 	})
+
+	registerWebhookMatrix()
 })
 
 func Describe(name string, args ...any) bool {
 	return ginkgo.Describe(name, labels.OSD, labels.ROSA, labels.STS, args)
 }
 
+// dumpArtifactsOnFailure dumps validation-webhook pod logs, daemonset
+// status, and namespace events into ${ARTIFACT_DIR}/webhooks/<spec-name>/
+// when the current spec failed, so CI failures are actionable without
+// re-running with --v=trace.
+func dumpArtifactsOnFailure(ctx context.Context) {
+	report := ginkgo.CurrentSpecReport()
+	if !report.Failed() {
+		return
+	}
+
+	dir := artifactDirFor(report.FullText())
+
+	if err := h.DumpPodLogs(ctx, namespaceName, "", dir); err != nil {
+		ginkgo.GinkgoWriter.Printf("failed to dump validation-webhook pod logs: %v\n", err)
+	}
+
+	if err := dumpDaemonSetStatus(ctx, dir); err != nil {
+		ginkgo.GinkgoWriter.Printf("failed to dump validation-webhook daemonset status: %v\n", err)
+	}
+}
+
+func artifactDirFor(specName string) string {
+	base := os.Getenv("ARTIFACT_DIR")
+	if base == "" {
+		base = "."
+	}
+	return filepath.Join(base, "webhooks", sanitizeForPath(specName))
+}
+
+func sanitizeForPath(name string) string {
+	return strings.NewReplacer(" ", "_", "/", "_").Replace(name)
+}
+
+func dumpDaemonSetStatus(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create artifact dir %s: %w", dir, err)
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := asUser(h, "").GetWithRetry(ctx, daemonsetName, namespaceName, ds); err != nil {
+		return fmt.Errorf("failed to get daemonset %s: %w", daemonsetName, err)
+	}
+
+	path := filepath.Join(dir, "daemonset-status.txt")
+	return os.WriteFile(path, []byte(fmt.Sprintf("%+v\n", ds.Status)), 0o644)
+}
+
 // move these helpers somewhere
 
 func newTestPod(name string) *v1.Pod {
@@ -149,12 +264,106 @@ func withNamespace(pod *v1.Pod, namespace string) *v1.Pod {
 	return pod
 }
 
-func asServiceAccount(h *helper.H, sa string) *resources.Resources {
+// systemNamespace reports whether name is a cluster-critical namespace
+// (default, kube-*, or any openshift-* one not already recognized as
+// SRE-privileged) that an ordinary authenticated persona has no RBAC to
+// create pods in. These must not land in the "unprivileged" bucket: sweeping
+// them in made the "are blocked" spec pass on a pre-admission RBAC denial
+// instead of actually exercising the pod-scheduling webhook.
+func systemNamespace(name string) bool {
+	return name == "default" || strings.HasPrefix(name, "kube-") || strings.HasPrefix(name, "openshift")
+}
+
+// discoverNamespaces lists the cluster's namespaces matching
+// selector.NamespaceSelector and splits them into the privileged
+// (srePrivilegedNamespaces) and unprivileged sets the pod-scheduling specs
+// exercise. Classification is against srePrivilegedNamespaces rather than an
+// "openshift-" prefix: several openshift-* namespaces (e.g.
+// openshift-logging) are not SRE-privileged for pod scheduling, so a prefix
+// match would contradict the "are allowed" spec's own entitlement set.
+// Namespaces the webhook doesn't actually govern for an ordinary persona
+// (systemNamespace) are dropped entirely rather than misclassified as
+// unprivileged. Both buckets are sorted so boundNamespaces truncates
+// deterministically instead of depending on API list order. This replaces
+// the hard-coded openshift-backplane/openshift-logging pair so the suite
+// stays portable across ROSA, OSD, and HyperShift clusters whose namespace
+// inventories differ.
+func discoverNamespaces(ctx context.Context, client *retry.Client, selector *config.WebhookTestSelector) (privileged, unprivileged []string, err error) {
+	nsSelector := k8slabels.Everything()
+	if selector != nil && selector.NamespaceSelector != nil {
+		nsSelector, err = metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+	}
+
+	var list v1.NamespaceList
+	if err := client.ListWithRetry(ctx, &list, resources.WithLabelSelector(nsSelector.String())); err != nil {
+		return nil, nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	for _, ns := range list.Items {
+		switch {
+		case isSREPrivilegedNamespace(ns.Name):
+			privileged = append(privileged, ns.Name)
+		case systemNamespace(ns.Name):
+			// Neither SRE-privileged nor reachable by an ordinary persona;
+			// excluded from both buckets.
+		default:
+			unprivileged = append(unprivileged, ns.Name)
+		}
+	}
+
+	sort.Strings(privileged)
+	sort.Strings(unprivileged)
+
+	if len(privileged) == 0 {
+		privileged = append(privileged, srePrivilegedNamespaces...)
+	}
+	if len(unprivileged) == 0 {
+		// h.CurrentProject() is the suite's own test project, the one
+		// non-system namespace an ordinary persona is guaranteed to reach.
+		unprivileged = []string{h.CurrentProject()}
+	}
+
+	return privileged, unprivileged, nil
+}
+
+// boundNamespaces truncates the (already sorted) names to at most max
+// entries, logging how many were dropped so a cluster with many matching
+// namespaces doesn't silently lose coverage while still keeping the spec
+// within its SpecTimeout.
+func boundNamespaces(names []string, max int) []string {
+	if len(names) <= max {
+		return names
+	}
+	ginkgo.GinkgoWriter.Printf("discovered %d namespaces, exercising only the first %d: %v\n", len(names), max, names[:max])
+	return names[:max]
+}
+
+// withPodSelectorLabels applies selector.PodSelector.MatchLabels to pod, so
+// clusters that scope WEBHOOK_TEST_SELECTOR down to a specific pod label set
+// (e.g. to avoid colliding with a NetworkPolicy or PDB tied to that label)
+// get test pods that actually match it.
+func withPodSelectorLabels(pod *v1.Pod, selector *config.WebhookTestSelector) *v1.Pod {
+	if selector == nil || selector.PodSelector == nil || len(selector.PodSelector.MatchLabels) == 0 {
+		return pod
+	}
+	if pod.ObjectMeta.Labels == nil {
+		pod.ObjectMeta.Labels = map[string]string{}
+	}
+	for k, v := range selector.PodSelector.MatchLabels {
+		pod.ObjectMeta.Labels[k] = v
+	}
+	return pod
+}
+
+func asServiceAccount(h *helper.H, sa string) *retry.Client {
 	h.ServiceAccount = sa
 	return asUser(h, sa)
 }
 
-func asUser(h *helper.H, user string, groups ...string) *resources.Resources {
+func asUser(h *helper.H, user string, groups ...string) *retry.Client {
 	// these groups are required for impersonating a user
 	if user != "" {
 		groups = append(groups, "system:authenticated", "system:authenticated:oauth")
@@ -168,13 +377,24 @@ func asUser(h *helper.H, user string, groups ...string) *resources.Resources {
 	client, err := resources.New(h.GetConfig())
 	Expect(err).NotTo(HaveOccurred(), "failed to create resources client object")
 
-	return client
+	// Wrap the client so specs don't flake when the validation-webhook
+	// daemonset is mid-restart and transiently unreachable.
+	return retry.New(client, createPodWaitDuration)
 }
 
-func asDedicatedAdmin(h *helper.H) *resources.Resources {
+func asDedicatedAdmin(h *helper.H) *retry.Client {
 	return asUser(h, "test-user@redhat.com", "dedicated-admins")
 }
 
+// asBackplaneClusterAdmin impersonates an SRE accessing the cluster through
+// Backplane, RBAC-elevated well beyond dedicated-admins. Some webhooks (e.g.
+// hiveownership, sre-scc) exist specifically to deny actions this persona's
+// RBAC would otherwise permit, so exercising them with a lesser persona would
+// only prove an RBAC denial, not the webhook.
+func asBackplaneClusterAdmin(h *helper.H) *retry.Client {
+	return asUser(h, "backplane-cluster-admin", "backplane-cluster-admins")
+}
+
 func waitForDaemonSetAvailable(resources *resources.Resources, name string, namespace string) error {
 	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
 	return wait.For(conditions.New(resources).ResourceMatch(ds, func(object k8s.Object) bool {